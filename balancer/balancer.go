@@ -0,0 +1,129 @@
+// Package balancer defines APIs for client-side load balancing in wsrpc,
+// mirroring the shape of grpc-go's balancer package. A Balancer consumes
+// resolver.State updates, creates one SubConn per address it cares about,
+// and produces a Picker which ClientConn.Invoke consults for every call.
+package balancer
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/smartcontractkit/wsrpc/connectivity"
+	"github.com/smartcontractkit/wsrpc/resolver"
+)
+
+// ErrNoSubConnAvailable is returned by a Picker when no SubConn is currently
+// ready to serve a call.
+var ErrNoSubConnAvailable = errors.New("balancer: no SubConn is available")
+
+// ErrBadResolverState may be returned by UpdateClientConnState to indicate
+// that the resolver.State is unusable (e.g. it has no addresses).
+var ErrBadResolverState = errors.New("balancer: bad resolver state")
+
+// SubConn represents a connection to a single address returned by the
+// resolver. It is created via ClientConn.NewSubConn and is opaque to the
+// Balancer beyond the methods below.
+type SubConn interface {
+	// Connect starts connecting the SubConn if it is Idle.
+	Connect()
+}
+
+// NewSubConnOptions contains options for ClientConn.NewSubConn.
+type NewSubConnOptions struct{}
+
+// State is pushed by a Balancer to the ClientConn via ClientConn.UpdateState
+// whenever the aggregate connectivity state or the Picker changes.
+type State struct {
+	ConnectivityState connectivity.State
+	Picker            Picker
+}
+
+// ClientConn is the interface a Balancer uses to create SubConns and push
+// Picker/connectivity updates back to the owning wsrpc.ClientConn.
+type ClientConn interface {
+	// NewSubConn creates a SubConn for one of the given addresses.
+	NewSubConn([]resolver.Address, NewSubConnOptions) (SubConn, error)
+	// RemoveSubConn removes sc, tearing it down if necessary.
+	RemoveSubConn(SubConn)
+	// UpdateState notifies the ClientConn of a new aggregate State.
+	UpdateState(State)
+}
+
+// ClientConnState contains the information pushed by the ClientConn to the
+// Balancer on every resolver update.
+type ClientConnState struct {
+	ResolverState resolver.State
+}
+
+// SubConnState describes a SubConn's connectivity state.
+type SubConnState struct {
+	ConnectivityState connectivity.State
+}
+
+// BuildOptions contains additional information for Build.
+type BuildOptions struct{}
+
+// Balancer takes resolver updates for a target and a stream of SubConn
+// connectivity changes, and decides which SubConn(s) should serve RPCs via
+// the Picker it reports to ClientConn.UpdateState.
+type Balancer interface {
+	// UpdateClientConnState is called by the ClientConn whenever the
+	// resolver produces a new State.
+	UpdateClientConnState(ClientConnState) error
+	// ResolverError is called by the ClientConn when the resolver reports
+	// an error.
+	ResolverError(error)
+	// UpdateSubConnState is called by the ClientConn whenever a SubConn it
+	// owns changes connectivity state.
+	UpdateSubConnState(SubConn, SubConnState)
+	// Close shuts down the balancer.
+	Close()
+}
+
+// Builder creates a Balancer.
+type Builder interface {
+	// Build creates a new Balancer bound to cc.
+	Build(cc ClientConn, opts BuildOptions) Balancer
+	// Name returns the name under which this Builder is registered, e.g.
+	// "round_robin".
+	Name() string
+}
+
+// PickInfo contains additional information for Pick.
+type PickInfo struct {
+	// Method is the RPC method being invoked.
+	Method string
+}
+
+// PickResult is returned by a Picker's Pick.
+type PickResult struct {
+	SubConn SubConn
+}
+
+// Picker selects a SubConn for each outgoing RPC.
+type Picker interface {
+	// Pick returns the SubConn to use for the RPC described by info, or
+	// ErrNoSubConnAvailable if none is currently ready.
+	Pick(info PickInfo) (PickResult, error)
+}
+
+var (
+	mu       sync.RWMutex
+	builders = map[string]Builder{}
+)
+
+// Register registers the Builder under b.Name(). Registering a second
+// Builder under an already-registered name overwrites the first.
+func Register(b Builder) {
+	mu.Lock()
+	defer mu.Unlock()
+	builders[b.Name()] = b
+}
+
+// Get returns the Builder registered under name, or nil if none is
+// registered.
+func Get(name string) Builder {
+	mu.RLock()
+	defer mu.RUnlock()
+	return builders[name]
+}
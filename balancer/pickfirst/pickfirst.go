@@ -0,0 +1,74 @@
+// Package pickfirst implements the pick_first balancer: it connects to the
+// first address the resolver reports and sticks with it. The other
+// addresses and later resolver updates are ignored; the SubConn is never
+// replaced.
+package pickfirst
+
+import (
+	"github.com/smartcontractkit/wsrpc/balancer"
+	"github.com/smartcontractkit/wsrpc/connectivity"
+)
+
+// Name is the name registered for this balancer.
+const Name = "pick_first"
+
+func init() {
+	balancer.Register(&builder{})
+}
+
+type builder struct{}
+
+func (*builder) Name() string { return Name }
+
+func (*builder) Build(cc balancer.ClientConn, _ balancer.BuildOptions) balancer.Balancer {
+	return &pickFirstBalancer{cc: cc}
+}
+
+type pickFirstBalancer struct {
+	cc    balancer.ClientConn
+	sc    balancer.SubConn
+	state connectivity.State
+}
+
+func (b *pickFirstBalancer) UpdateClientConnState(s balancer.ClientConnState) error {
+	if len(s.ResolverState.Addresses) == 0 {
+		return balancer.ErrBadResolverState
+	}
+
+	if b.sc != nil {
+		return nil
+	}
+
+	sc, err := b.cc.NewSubConn(s.ResolverState.Addresses[:1], balancer.NewSubConnOptions{})
+	if err != nil {
+		return err
+	}
+	b.sc = sc
+	sc.Connect()
+
+	return nil
+}
+
+func (b *pickFirstBalancer) ResolverError(error) {}
+
+func (b *pickFirstBalancer) UpdateSubConnState(sc balancer.SubConn, s balancer.SubConnState) {
+	b.state = s.ConnectivityState
+	b.cc.UpdateState(balancer.State{
+		ConnectivityState: b.state,
+		Picker:            &picker{sc: sc, ready: b.state == connectivity.Ready},
+	})
+}
+
+func (b *pickFirstBalancer) Close() {}
+
+type picker struct {
+	sc    balancer.SubConn
+	ready bool
+}
+
+func (p *picker) Pick(balancer.PickInfo) (balancer.PickResult, error) {
+	if !p.ready {
+		return balancer.PickResult{}, balancer.ErrNoSubConnAvailable
+	}
+	return balancer.PickResult{SubConn: p.sc}, nil
+}
@@ -0,0 +1,121 @@
+// Package roundrobin implements the round_robin balancer: it connects to
+// every address the resolver reports and spreads RPCs evenly across the
+// ones that are currently Ready.
+package roundrobin
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/smartcontractkit/wsrpc/balancer"
+	"github.com/smartcontractkit/wsrpc/connectivity"
+	"github.com/smartcontractkit/wsrpc/resolver"
+)
+
+// Name is the name registered for this balancer.
+const Name = "round_robin"
+
+func init() {
+	balancer.Register(&builder{})
+}
+
+type builder struct{}
+
+func (*builder) Name() string { return Name }
+
+func (*builder) Build(cc balancer.ClientConn, _ balancer.BuildOptions) balancer.Balancer {
+	return &roundRobinBalancer{cc: cc, states: map[balancer.SubConn]connectivity.State{}}
+}
+
+type roundRobinBalancer struct {
+	cc balancer.ClientConn
+
+	mu      sync.Mutex
+	scs     []balancer.SubConn
+	states  map[balancer.SubConn]connectivity.State
+	started bool
+}
+
+func (b *roundRobinBalancer) UpdateClientConnState(s balancer.ClientConnState) error {
+	if len(s.ResolverState.Addresses) == 0 {
+		return balancer.ErrBadResolverState
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.started {
+		return nil
+	}
+	b.started = true
+
+	for _, addr := range s.ResolverState.Addresses {
+		sc, err := b.cc.NewSubConn([]resolver.Address{addr}, balancer.NewSubConnOptions{})
+		if err != nil {
+			return err
+		}
+		b.scs = append(b.scs, sc)
+		b.states[sc] = connectivity.Idle
+		sc.Connect()
+	}
+
+	return nil
+}
+
+func (b *roundRobinBalancer) ResolverError(error) {}
+
+func (b *roundRobinBalancer) UpdateSubConnState(sc balancer.SubConn, s balancer.SubConnState) {
+	b.mu.Lock()
+	b.states[sc] = s.ConnectivityState
+	ready := make([]balancer.SubConn, 0, len(b.scs))
+	for _, c := range b.scs {
+		if b.states[c] == connectivity.Ready {
+			ready = append(ready, c)
+		}
+	}
+	b.mu.Unlock()
+
+	b.cc.UpdateState(balancer.State{
+		ConnectivityState: aggregate(b.states),
+		Picker:            &picker{ready: ready},
+	})
+}
+
+func (b *roundRobinBalancer) Close() {}
+
+// aggregate follows grpc's connectivity aggregation rules: Ready if any
+// SubConn is Ready, else Connecting if any is Connecting, else
+// TransientFailure if any is TransientFailure, else Idle.
+func aggregate(states map[balancer.SubConn]connectivity.State) connectivity.State {
+	var connecting, failing int
+	for _, s := range states {
+		switch s {
+		case connectivity.Ready:
+			return connectivity.Ready
+		case connectivity.Connecting:
+			connecting++
+		case connectivity.TransientFailure:
+			failing++
+		}
+	}
+	if connecting > 0 {
+		return connectivity.Connecting
+	}
+	if failing > 0 {
+		return connectivity.TransientFailure
+	}
+	return connectivity.Idle
+}
+
+type picker struct {
+	ready []balancer.SubConn
+	next  uint32
+}
+
+func (p *picker) Pick(balancer.PickInfo) (balancer.PickResult, error) {
+	if len(p.ready) == 0 {
+		return balancer.PickResult{}, balancer.ErrNoSubConnAvailable
+	}
+	i := atomic.AddUint32(&p.next, 1)
+	return balancer.PickResult{SubConn: p.ready[int(i)%len(p.ready)]}, nil
+}
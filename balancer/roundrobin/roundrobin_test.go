@@ -0,0 +1,62 @@
+package roundrobin
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/wsrpc/balancer"
+	"github.com/smartcontractkit/wsrpc/connectivity"
+)
+
+type fakeSubConn struct{ id int }
+
+func (*fakeSubConn) Connect() {}
+
+func TestAggregateReadyIfAnyReady(t *testing.T) {
+	a, b := &fakeSubConn{1}, &fakeSubConn{2}
+	states := map[balancer.SubConn]connectivity.State{
+		a: connectivity.TransientFailure,
+		b: connectivity.Ready,
+	}
+	if got := aggregate(states); got != connectivity.Ready {
+		t.Errorf("aggregate(%v) = %v, want %v", states, got, connectivity.Ready)
+	}
+}
+
+func TestAggregateConnectingIfAnyConnecting(t *testing.T) {
+	a, b := &fakeSubConn{1}, &fakeSubConn{2}
+	states := map[balancer.SubConn]connectivity.State{
+		a: connectivity.TransientFailure,
+		b: connectivity.Connecting,
+	}
+	if got := aggregate(states); got != connectivity.Connecting {
+		t.Errorf("aggregate(%v) = %v, want %v", states, got, connectivity.Connecting)
+	}
+}
+
+func TestAggregateTransientFailureIfAnyFailingAndNoneReadyOrConnecting(t *testing.T) {
+	a, b := &fakeSubConn{1}, &fakeSubConn{2}
+	states := map[balancer.SubConn]connectivity.State{
+		a: connectivity.Idle,
+		b: connectivity.TransientFailure,
+	}
+	if got := aggregate(states); got != connectivity.TransientFailure {
+		t.Errorf("aggregate(%v) = %v, want %v", states, got, connectivity.TransientFailure)
+	}
+}
+
+func TestAggregateIdleIfNoneFailingConnectingOrReady(t *testing.T) {
+	a, b := &fakeSubConn{1}, &fakeSubConn{2}
+	states := map[balancer.SubConn]connectivity.State{
+		a: connectivity.Idle,
+		b: connectivity.Idle,
+	}
+	if got := aggregate(states); got != connectivity.Idle {
+		t.Errorf("aggregate(%v) = %v, want %v", states, got, connectivity.Idle)
+	}
+}
+
+func TestAggregateEmptyIsIdle(t *testing.T) {
+	if got := aggregate(map[balancer.SubConn]connectivity.State{}); got != connectivity.Idle {
+		t.Errorf("aggregate(nil) = %v, want %v", got, connectivity.Idle)
+	}
+}
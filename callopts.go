@@ -0,0 +1,44 @@
+package wsrpc
+
+import "time"
+
+// callOptions configure an Invoke/InvokeContext call. callOptions are set by
+// the CallOption values passed to Invoke.
+type callOptions struct {
+	timeout time.Duration
+}
+
+// CallOption configures a Call before it starts or extracts information from
+// a Call after it completes.
+type CallOption interface {
+	apply(*callOptions)
+}
+
+// funcCallOption wraps a function that modifies callOptions into an
+// implementation of the CallOption interface.
+type funcCallOption struct {
+	f func(*callOptions)
+}
+
+func (fco *funcCallOption) apply(co *callOptions) {
+	fco.f(co)
+}
+
+func newFuncCallOption(f func(*callOptions)) *funcCallOption {
+	return &funcCallOption{
+		f: f,
+	}
+}
+
+// CallTimeout returns a CallOption that overrides the dial-level call timeout
+// for this call only. It has no effect if the context passed to
+// InvokeContext already carries a deadline.
+func CallTimeout(d time.Duration) CallOption {
+	return newFuncCallOption(func(o *callOptions) {
+		o.timeout = d
+	})
+}
+
+func defaultCallOptions() callOptions {
+	return callOptions{}
+}
@@ -0,0 +1,229 @@
+// Package channelz provides read-only introspection into wsrpc
+// ClientConns, addrConns, transports, and in-flight calls, modeled after
+// grpc's channelz. It intentionally has no dependency on the root wsrpc
+// package; callers register and update Channels/Sockets as they go through
+// their lifecycle.
+package channelz
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Channel tracks a single ClientConn or addrConn for introspection.
+type Channel struct {
+	ID     int64
+	Target string
+
+	callsStarted   int64
+	callsSucceeded int64
+	callsFailed    int64
+	transientFails int64
+
+	mu                       sync.RWMutex
+	state                    string
+	backoffActive            bool
+	lastCallStartedTimestamp time.Time
+}
+
+// CallStarted records the start of a call and bumps the last-call-started
+// timestamp.
+func (c *Channel) CallStarted() {
+	atomic.AddInt64(&c.callsStarted, 1)
+	c.mu.Lock()
+	c.lastCallStartedTimestamp = time.Now()
+	c.mu.Unlock()
+}
+
+// CallSucceeded records a successfully completed call.
+func (c *Channel) CallSucceeded() {
+	atomic.AddInt64(&c.callsSucceeded, 1)
+}
+
+// CallFailed records a failed call.
+func (c *Channel) CallFailed() {
+	atomic.AddInt64(&c.callsFailed, 1)
+}
+
+// SetState records the Channel's current connectivity state, as reported by
+// connectivity.State.String().
+func (c *Channel) SetState(s string) {
+	c.mu.Lock()
+	c.state = s
+	c.mu.Unlock()
+}
+
+// SetBackoffActive records whether the Channel is currently waiting out a
+// reconnection backoff.
+func (c *Channel) SetBackoffActive(active bool) {
+	c.mu.Lock()
+	c.backoffActive = active
+	c.mu.Unlock()
+}
+
+// IncrTransientFailureCount records a transition into TRANSIENT_FAILURE.
+func (c *Channel) IncrTransientFailureCount() {
+	atomic.AddInt64(&c.transientFails, 1)
+}
+
+// Snapshot is a point-in-time, read-only copy of a Channel's counters,
+// suitable for JSON encoding.
+type Snapshot struct {
+	ID                       int64     `json:"id"`
+	Target                   string    `json:"target"`
+	State                    string    `json:"state"`
+	BackoffActive            bool      `json:"backoffActive"`
+	CallsStarted             int64     `json:"callsStarted"`
+	CallsSucceeded           int64     `json:"callsSucceeded"`
+	CallsFailed              int64     `json:"callsFailed"`
+	TransientFailureCount    int64     `json:"transientFailureCount"`
+	LastCallStartedTimestamp time.Time `json:"lastCallStartedTimestamp"`
+}
+
+// Snapshot returns a point-in-time copy of c's counters.
+func (c *Channel) Snapshot() Snapshot {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return Snapshot{
+		ID:                       c.ID,
+		Target:                   c.Target,
+		State:                    c.state,
+		BackoffActive:            c.backoffActive,
+		CallsStarted:             atomic.LoadInt64(&c.callsStarted),
+		CallsSucceeded:           atomic.LoadInt64(&c.callsSucceeded),
+		CallsFailed:              atomic.LoadInt64(&c.callsFailed),
+		TransientFailureCount:    atomic.LoadInt64(&c.transientFails),
+		LastCallStartedTimestamp: c.lastCallStartedTimestamp,
+	}
+}
+
+// Socket tracks a single transport connection for introspection.
+type Socket struct {
+	ID         int64
+	RemoteAddr string
+
+	messagesSent     int64
+	messagesReceived int64
+	bytesSent        int64
+	bytesReceived    int64
+	lastActivity     int64 // unix nanoseconds, atomic
+}
+
+// MessageSent records a message of n bytes written to the socket.
+func (s *Socket) MessageSent(n int) {
+	atomic.AddInt64(&s.messagesSent, 1)
+	atomic.AddInt64(&s.bytesSent, int64(n))
+	atomic.StoreInt64(&s.lastActivity, time.Now().UnixNano())
+}
+
+// MessageReceived records a message of n bytes read from the socket.
+func (s *Socket) MessageReceived(n int) {
+	atomic.AddInt64(&s.messagesReceived, 1)
+	atomic.AddInt64(&s.bytesReceived, int64(n))
+	atomic.StoreInt64(&s.lastActivity, time.Now().UnixNano())
+}
+
+// LastActivity returns the time of the most recent MessageSent or
+// MessageReceived call, or the zero Time if neither has happened yet.
+func (s *Socket) LastActivity() time.Time {
+	ns := atomic.LoadInt64(&s.lastActivity)
+	if ns == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, ns)
+}
+
+// SocketSnapshot is a point-in-time, read-only copy of a Socket's counters.
+type SocketSnapshot struct {
+	ID               int64  `json:"id"`
+	RemoteAddr       string `json:"remoteAddr"`
+	MessagesSent     int64  `json:"messagesSent"`
+	MessagesReceived int64  `json:"messagesReceived"`
+	BytesSent        int64  `json:"bytesSent"`
+	BytesReceived    int64  `json:"bytesReceived"`
+}
+
+// Snapshot returns a point-in-time copy of s's counters.
+func (s *Socket) Snapshot() SocketSnapshot {
+	return SocketSnapshot{
+		ID:               s.ID,
+		RemoteAddr:       s.RemoteAddr,
+		MessagesSent:     atomic.LoadInt64(&s.messagesSent),
+		MessagesReceived: atomic.LoadInt64(&s.messagesReceived),
+		BytesSent:        atomic.LoadInt64(&s.bytesSent),
+		BytesReceived:    atomic.LoadInt64(&s.bytesReceived),
+	}
+}
+
+var (
+	mu       sync.RWMutex
+	nextID   int64
+	channels = map[int64]*Channel{}
+	sockets  = map[int64]*Socket{}
+)
+
+// RegisterChannel registers a new Channel for target and returns it.
+func RegisterChannel(target string) *Channel {
+	mu.Lock()
+	defer mu.Unlock()
+
+	nextID++
+	c := &Channel{ID: nextID, Target: target}
+	channels[c.ID] = c
+	return c
+}
+
+// RemoveChannel deregisters a Channel.
+func RemoveChannel(id int64) {
+	mu.Lock()
+	defer mu.Unlock()
+	delete(channels, id)
+}
+
+// RegisterSocket registers a new Socket for remoteAddr and returns it.
+func RegisterSocket(remoteAddr string) *Socket {
+	mu.Lock()
+	defer mu.Unlock()
+
+	nextID++
+	s := &Socket{ID: nextID, RemoteAddr: remoteAddr, lastActivity: time.Now().UnixNano()}
+	sockets[s.ID] = s
+	return s
+}
+
+// RemoveSocket deregisters a Socket.
+func RemoveSocket(id int64) {
+	mu.Lock()
+	defer mu.Unlock()
+	delete(sockets, id)
+}
+
+// GetTopChannels returns every currently registered Channel. This chunk
+// doesn't yet distinguish top-level ClientConns from their per-address
+// subchannels, so both are returned together.
+func GetTopChannels() []*Channel {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	out := make([]*Channel, 0, len(channels))
+	for _, c := range channels {
+		out = append(out, c)
+	}
+	return out
+}
+
+// GetChannel returns the Channel registered under id, or nil if none is.
+func GetChannel(id int64) *Channel {
+	mu.RLock()
+	defer mu.RUnlock()
+	return channels[id]
+}
+
+// GetSocket returns the Socket registered under id, or nil if none is.
+func GetSocket(id int64) *Socket {
+	mu.RLock()
+	defer mu.RUnlock()
+	return sockets[id]
+}
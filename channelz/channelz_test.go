@@ -0,0 +1,86 @@
+package channelz
+
+import "testing"
+
+func TestChannelCountersAccumulate(t *testing.T) {
+	c := RegisterChannel("test-target")
+	defer RemoveChannel(c.ID)
+
+	c.CallStarted()
+	c.CallStarted()
+	c.CallSucceeded()
+	c.CallFailed()
+	c.IncrTransientFailureCount()
+	c.SetState("READY")
+	c.SetBackoffActive(true)
+
+	snap := c.Snapshot()
+	if snap.CallsStarted != 2 {
+		t.Errorf("CallsStarted = %d, want 2", snap.CallsStarted)
+	}
+	if snap.CallsSucceeded != 1 {
+		t.Errorf("CallsSucceeded = %d, want 1", snap.CallsSucceeded)
+	}
+	if snap.CallsFailed != 1 {
+		t.Errorf("CallsFailed = %d, want 1", snap.CallsFailed)
+	}
+	if snap.TransientFailureCount != 1 {
+		t.Errorf("TransientFailureCount = %d, want 1", snap.TransientFailureCount)
+	}
+	if snap.State != "READY" {
+		t.Errorf("State = %q, want %q", snap.State, "READY")
+	}
+	if !snap.BackoffActive {
+		t.Error("BackoffActive = false, want true")
+	}
+	if snap.LastCallStartedTimestamp.IsZero() {
+		t.Error("LastCallStartedTimestamp is zero, want non-zero after CallStarted")
+	}
+}
+
+func TestSocketCountersAccumulate(t *testing.T) {
+	s := RegisterSocket("127.0.0.1:1234")
+	defer RemoveSocket(s.ID)
+
+	s.MessageSent(10)
+	s.MessageSent(5)
+	s.MessageReceived(7)
+
+	snap := s.Snapshot()
+	if snap.MessagesSent != 2 {
+		t.Errorf("MessagesSent = %d, want 2", snap.MessagesSent)
+	}
+	if snap.BytesSent != 15 {
+		t.Errorf("BytesSent = %d, want 15", snap.BytesSent)
+	}
+	if snap.MessagesReceived != 1 {
+		t.Errorf("MessagesReceived = %d, want 1", snap.MessagesReceived)
+	}
+	if snap.BytesReceived != 7 {
+		t.Errorf("BytesReceived = %d, want 7", snap.BytesReceived)
+	}
+}
+
+func TestRegisterAndRemoveChannel(t *testing.T) {
+	c := RegisterChannel("target")
+	if got := GetChannel(c.ID); got != c {
+		t.Fatalf("GetChannel(%d) = %v, want %v", c.ID, got, c)
+	}
+
+	RemoveChannel(c.ID)
+	if got := GetChannel(c.ID); got != nil {
+		t.Errorf("GetChannel(%d) after RemoveChannel = %v, want nil", c.ID, got)
+	}
+}
+
+func TestRegisterAndRemoveSocket(t *testing.T) {
+	s := RegisterSocket("addr")
+	if got := GetSocket(s.ID); got != s {
+		t.Fatalf("GetSocket(%d) = %v, want %v", s.ID, got, s)
+	}
+
+	RemoveSocket(s.ID)
+	if got := GetSocket(s.ID); got != nil {
+		t.Errorf("GetSocket(%d) after RemoveSocket = %v, want nil", s.ID, got)
+	}
+}
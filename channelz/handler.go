@@ -0,0 +1,36 @@
+package channelz
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// snapshotResponse is the payload served by Handler.
+type snapshotResponse struct {
+	Channels []Snapshot       `json:"channels"`
+	Sockets  []SocketSnapshot `json:"sockets"`
+}
+
+// Handler returns an http.Handler serving a read-only JSON snapshot of
+// every registered Channel and Socket. Operators can mount it at a debug
+// path, e.g. http.Handle("/debug/wsrpc/channelz", channelz.Handler()).
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		channels := GetTopChannels()
+		resp := snapshotResponse{
+			Channels: make([]Snapshot, 0, len(channels)),
+		}
+		for _, c := range channels {
+			resp.Channels = append(resp.Channels, c.Snapshot())
+		}
+
+		mu.RLock()
+		for _, s := range sockets {
+			resp.Sockets = append(resp.Sockets, s.Snapshot())
+		}
+		mu.RUnlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	})
+}
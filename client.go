@@ -5,24 +5,34 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/smartcontractkit/wsrpc/balancer"
+	_ "github.com/smartcontractkit/wsrpc/balancer/pickfirst"
+	_ "github.com/smartcontractkit/wsrpc/balancer/roundrobin"
+	"github.com/smartcontractkit/wsrpc/channelz"
 	"github.com/smartcontractkit/wsrpc/connectivity"
 	"github.com/smartcontractkit/wsrpc/internal/backoff"
 	"github.com/smartcontractkit/wsrpc/internal/message"
 	"github.com/smartcontractkit/wsrpc/internal/wsrpcsync"
+	"github.com/smartcontractkit/wsrpc/resolver"
 	"google.golang.org/protobuf/proto"
 )
 
+// defaultBalancerName is used when neither WithBalancerName nor a service
+// config picks a balancer.
+const defaultBalancerName = "pick_first"
+
 var (
 	// errConnClosing indicates that the connection is closing.
 	errConnClosing = errors.New("grpc: the connection is closing")
 )
 
 type ClientConnInterface interface {
-	Invoke(method string, args interface{}, reply interface{}) error
+	Invoke(method string, args interface{}, reply interface{}, opts ...CallOption) error
 }
 
 // ClientConn represents a virtual connection to a websocket endpoint, to
@@ -32,10 +42,17 @@ type ClientConn struct {
 	mu  sync.RWMutex
 
 	target string
-	csCh   <-chan connectivity.State
 
 	dopts dialOptions
-	conn  *addrConn
+
+	// conns holds one addrConn per address currently known to the resolver,
+	// keyed by address.
+	conns    map[string]*addrConn
+	resolver resolver.Resolver
+	bal      balancer.Balancer
+
+	pickerMu sync.Mutex
+	picker   balancer.Picker
 
 	// readFn contains the registered handler for reading messages
 	readFn func(message []byte)
@@ -43,83 +60,132 @@ type ClientConn struct {
 	// Contains all pending method call ids and the channel to respond to when
 	// a result is received
 	methodCalls map[string]chan<- []byte
+
+	// Contains all open streams, keyed by stream id.
+	streams map[string]*clientStream
+
+	// channelz is the introspection handle registered for this ClientConn.
+	channelz *channelz.Channel
 }
 
-// Dial creates a client connection to the given target.
+// Dial creates a client connection to the given target. target may be
+// scheme-qualified (e.g. "static:///h1,h2,h3", "dns:///example.com:1234")
+// to select a resolver; an unqualified target is resolved via the
+// passthrough resolver. Use WithBalancerName to pick a balancing policy
+// other than the "pick_first" default.
 func Dial(target string, opts ...DialOption) (*ClientConn, error) {
 	cc := &ClientConn{
 		ctx:         context.Background(),
 		target:      target,
 		dopts:       defaultDialOptions(),
+		conns:       map[string]*addrConn{},
 		methodCalls: map[string]chan<- []byte{},
+		streams:     map[string]*clientStream{},
+		channelz:    channelz.RegisterChannel(target),
 	}
 
 	for _, opt := range opts {
 		opt.apply(&cc.dopts)
 	}
 
-	// Set the backoff strategy. We may need to consider making this
-	// customizable in the dial options.
-	cc.dopts.bs = backoff.DefaultExponential
+	// WithConnectParams/WithBackoff may already have set a backoff strategy;
+	// fall back to the default exponential strategy otherwise.
+	if cc.dopts.bs == nil {
+		cc.dopts.bs = backoff.DefaultExponential
+	}
+
+	balancerName := cc.dopts.balancerName
+	if balancerName == "" {
+		balancerName = defaultBalancerName
+	}
+	bb := balancer.Get(balancerName)
+	if bb == nil {
+		return nil, fmt.Errorf("wsrpc: no balancer registered for name %q", balancerName)
+	}
+	cc.bal = bb.Build(&ccBalancerWrapper{cc: cc}, balancer.BuildOptions{})
 
-	addrConn, err := cc.newAddrConn(target)
+	rb := cc.dopts.resolverBuilder
+	parsedTarget := parseTarget(target)
+	if rb == nil {
+		rb = resolver.Get(parsedTarget.Scheme)
+	}
+	if rb == nil {
+		rb = resolver.Get("passthrough")
+	}
+
+	r, err := rb.Build(parsedTarget, &ccResolverWrapper{cc: cc}, resolver.BuildOptions{})
 	if err != nil {
 		return nil, errors.New("Could not establish a connection")
 	}
-
-	addrConn.connect()
-	cc.conn = addrConn
+	cc.resolver = r
 
 	return cc, nil
 }
 
-// newAddrConn creates an addrConn for the addr and sets it to cc.conn.
+// parseTarget splits a dial target of the form "scheme:///endpoint" into a
+// resolver.Target. Any "scheme:///" prefix is split off, whether or not a
+// resolver is registered for that scheme; a target with no "scheme:///"
+// prefix is treated as a bare endpoint for the passthrough resolver.
+func parseTarget(target string) resolver.Target {
+	const sep = ":///"
+	if i := strings.Index(target, sep); i >= 0 {
+		return resolver.Target{Scheme: target[:i], Endpoint: target[i+len(sep):]}
+	}
+	return resolver.Target{Scheme: "passthrough", Endpoint: target}
+}
+
+// newAddrConn creates an addrConn for addr and registers it in cc.conns.
 func (cc *ClientConn) newAddrConn(addr string) (*addrConn, error) {
-	csCh := make(chan connectivity.State)
 	ac := &addrConn{
-		state:   connectivity.Idle,
-		stateCh: csCh,
-		cc:      cc,
-		addr:    addr,
-		dopts:   cc.dopts,
+		state:    connectivity.Idle,
+		cc:       cc,
+		addr:     addr,
+		dopts:    cc.dopts,
+		channelz: channelz.RegisterChannel(addr),
 	}
 	ac.ctx, ac.cancel = context.WithCancel(cc.ctx)
-	cc.mu.Lock()
 
-	cc.conn = ac
-	cc.csCh = csCh
+	cc.mu.Lock()
+	cc.conns[addr] = ac
 	cc.mu.Unlock()
 
-	go cc.listenForRead()
-
 	return ac, nil
 }
 
-// listenForRead listens for the connectivty state to be ready and enables the
-// read handler
-func (cc *ClientConn) listenForRead() {
-	for {
-		s := <-cc.csCh
+// updateResolverState forwards a new resolver.State to the balancer.
+func (cc *ClientConn) updateResolverState(s resolver.State) {
+	cc.mu.RLock()
+	bal := cc.bal
+	cc.mu.RUnlock()
 
-		var done chan struct{}
-
-		if s == connectivity.Ready {
-			done := make(chan struct{})
-			go cc.handleRead(done)
-		} else {
-			if done != nil {
-				close(done)
-			}
-		}
+	if bal == nil {
+		return
+	}
+	if err := bal.UpdateClientConnState(balancer.ClientConnState{ResolverState: s}); err != nil {
+		log.Println("[ClientConn] balancer rejected resolver state:", err)
 	}
 }
 
-// handleRead listens to the transport read channel and passes the message to the
-// readFn handler.
-func (cc *ClientConn) handleRead(done <-chan struct{}) {
+// updatePicker installs the Picker (and aggregate connectivity state)
+// reported by the balancer.
+func (cc *ClientConn) updatePicker(s balancer.State) {
+	cc.pickerMu.Lock()
+	cc.picker = s.Picker
+	cc.pickerMu.Unlock()
+
+	log.Printf("[ClientConn] Connectivity State: %s", s.ConnectivityState)
+}
+
+// handleRead listens to ac's transport read channel and passes messages to
+// the readFn handler until done is closed.
+func (cc *ClientConn) handleRead(ac *addrConn, done <-chan struct{}) {
 	for {
 		select {
-		case in := <-cc.conn.transport.Read():
+		case in := <-ac.transport.Read():
+			if ac.socket != nil {
+				ac.socket.MessageReceived(len(in))
+			}
+
 			// Unmarshal the message
 			msg := &message.Message{}
 			if err := UnmarshalProtoMessage(in, msg); err != nil {
@@ -127,6 +193,10 @@ func (cc *ClientConn) handleRead(done <-chan struct{}) {
 				continue
 			}
 
+			// TODO - dispatching inbound stream-msg/stream-end frames to
+			// cc.handleStreamMessage/cc.handleStreamEnd belongs here, once
+			// internal/message grows stream-open/stream-msg/half-close/
+			// stream-end exchange types alongside Request/Response.
 			switch ex := msg.Exchange.(type) {
 			case *message.Message_Request:
 				fmt.Println("Request:", msg)
@@ -157,18 +227,97 @@ func (cc *ClientConn) handleMessageResponse(r *message.Response) {
 
 // Close tears down the ClientConn and all underlying connections.
 func (cc *ClientConn) Close() {
-	conn := cc.conn
-
 	cc.mu.Lock()
-	cc.conn = nil
+	conns := cc.conns
+	cc.conns = map[string]*addrConn{}
+	r := cc.resolver
+	bal := cc.bal
 	cc.mu.Unlock()
 
-	conn.teardown()
+	if r != nil {
+		r.Close()
+	}
+	if bal != nil {
+		bal.Close()
+	}
+	for _, ac := range conns {
+		ac.teardown()
+	}
+
+	channelz.RemoveChannel(cc.channelz.ID)
 }
 
-func (cc *ClientConn) Invoke(method string, args interface{}, reply interface{}) error {
-	// Ensure the connection state is ready
-	if cc.conn.state != connectivity.Ready {
+// Invoke sends an RPC request on the wire and blocks until a response is
+// returned. It is a thin wrapper around InvokeContext which applies the
+// dial-level default call timeout (see WithCallTimeout).
+func (cc *ClientConn) Invoke(method string, args interface{}, reply interface{}, opts ...CallOption) error {
+	// Leave ctx without a deadline so InvokeContext's default-timeout logic
+	// (dial-level callTimeout, overridable by a CallTimeout CallOption) is
+	// the one place that decides the effective timeout.
+	return cc.InvokeContext(cc.ctx, method, args, reply, opts...)
+}
+
+// InvokeContext sends an RPC request on the wire and blocks until a response
+// is returned or ctx is done, whichever happens first. If ctx carries no
+// deadline, the dial-level default call timeout (or a CallTimeout CallOption)
+// is applied. Cancelling ctx removes the pending call locally; the server is
+// not notified and its handler runs to completion.
+func (cc *ClientConn) InvokeContext(ctx context.Context, method string, args interface{}, reply interface{}, opts ...CallOption) error {
+	if cc.dopts.unaryInt != nil {
+		return cc.dopts.unaryInt(ctx, method, args, reply, cc, invoke, opts...)
+	}
+	return invoke(ctx, method, args, reply, cc, opts...)
+}
+
+// invoke performs the request/response exchange over the wire. It is the
+// terminal UnaryInvoker at the end of the client interceptor chain.
+func invoke(ctx context.Context, method string, args interface{}, reply interface{}, cc *ClientConn, opts ...CallOption) (err error) {
+	cc.channelz.CallStarted()
+	defer func() {
+		if err != nil {
+			cc.channelz.CallFailed()
+		} else {
+			cc.channelz.CallSucceeded()
+		}
+	}()
+
+	copts := defaultCallOptions()
+	for _, opt := range opts {
+		opt.apply(&copts)
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		timeout := copts.timeout
+		if timeout == 0 {
+			timeout = cc.dopts.callTimeout
+		}
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+	}
+
+	// Ask the balancer's picker for a ready subconn to carry this call.
+	cc.pickerMu.Lock()
+	picker := cc.picker
+	cc.pickerMu.Unlock()
+
+	if picker == nil {
+		return errors.New("connection is not ready")
+	}
+
+	pr, err := picker.Pick(balancer.PickInfo{Method: method})
+	if err != nil {
+		return err
+	}
+
+	ac, ok := pr.SubConn.(*addrConn)
+	if !ok {
+		return errors.New("connection is not ready")
+	}
+	tr, socket := ac.getTransport()
+	if tr == nil {
 		return errors.New("connection is not ready")
 	}
 
@@ -199,7 +348,10 @@ func (cc *ClientConn) Invoke(method string, args interface{}, reply interface{})
 	wait := cc.registerMethodCall(callID)
 	cc.mu.Unlock()
 
-	cc.conn.transport.Write(msgB)
+	tr.Write(msgB)
+	if socket != nil {
+		socket.MessageSent(len(msgB))
+	}
 
 	// Wait for the response
 	select {
@@ -209,17 +361,24 @@ func (cc *ClientConn) Invoke(method string, args interface{}, reply interface{})
 		if err != nil {
 			return err
 		}
-	case <-time.After(2 * time.Second): // TODO - Make this configurable
-		// Remove the call since we have timeout
+	case <-ctx.Done():
+		// Remove the call since the caller is no longer waiting.
 		cc.mu.Lock()
 		cc.removeMethodCall(callID)
 		cc.mu.Unlock()
-		return errors.New("call timeout")
+		cc.sendCancel(callID)
+		return ctx.Err()
 	}
 
 	return nil
 }
 
+// sendCancel is called when the client abandons callID. It sends nothing:
+// internal/message has no Cancel exchange type, only Request and Response,
+// so the server is not told that the call was abandoned.
+func (cc *ClientConn) sendCancel(callID string) {
+}
+
 // registerMethodCall registers a method call to the method call map.
 //
 // This requires a lock on cc.mu.
@@ -237,6 +396,14 @@ func (cc *ClientConn) removeMethodCall(id string) {
 	delete(cc.methodCalls, id)
 }
 
+// hasInFlightCalls reports whether any method call is currently awaiting a
+// response.
+func (cc *ClientConn) hasInFlightCalls() bool {
+	cc.mu.RLock()
+	defer cc.mu.RUnlock()
+	return len(cc.methodCalls) > 0
+}
+
 // addrConn is a network connection to a given address.
 type addrConn struct {
 	ctx    context.Context
@@ -256,8 +423,31 @@ type addrConn struct {
 
 	// Use updateConnectivityState for updating addrConn's connectivity state.
 	state connectivity.State
-	// Notifies this channel when the ConnectivityState changes
-	stateCh chan connectivity.State
+
+	// readDone, when non-nil, is closed to stop the goroutine reading from
+	// transport; it is set while state is Ready.
+	readDone chan struct{}
+
+	// channelz is the introspection handle registered for this addrConn.
+	channelz *channelz.Channel
+	// socket is the introspection handle for the current transport; it is
+	// non-nil only while state is Ready.
+	socket *channelz.Socket
+}
+
+// Connect implements balancer.SubConn, starting a connection attempt if the
+// addrConn is currently Idle.
+func (ac *addrConn) Connect() {
+	ac.connect()
+}
+
+// getTransport returns ac's current transport and socket under ac.mu, so
+// callers on another goroutine (e.g. invoke, NewStream) don't race with
+// updateConnectivityState/resetTransport mutating them.
+func (ac *addrConn) getTransport() (ClientTransport, *channelz.Socket) {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	return ac.transport, ac.socket
 }
 
 // connect starts creating a transport.
@@ -291,8 +481,38 @@ func (ac *addrConn) updateConnectivityState(s connectivity.State) {
 		return
 	}
 	ac.state = s
-	ac.stateCh <- s
 	log.Printf("[AddrConn] Connectivity State: %s", s)
+
+	ac.channelz.SetState(s.String())
+	ac.channelz.SetBackoffActive(s == connectivity.TransientFailure)
+	if s == connectivity.TransientFailure {
+		ac.channelz.IncrTransientFailureCount()
+	}
+
+	if ac.cc.bal != nil {
+		ac.cc.bal.UpdateSubConnState(ac, balancer.SubConnState{ConnectivityState: s})
+	}
+
+	if s == connectivity.Ready {
+		ac.socket = channelz.RegisterSocket(ac.addr)
+
+		done := make(chan struct{})
+		ac.readDone = done
+		go ac.cc.handleRead(ac, done)
+
+		if ac.dopts.kp.Time > 0 {
+			go ac.monitorKeepalive(ac.socket, done)
+		}
+	} else {
+		if ac.socket != nil {
+			channelz.RemoveSocket(ac.socket.ID)
+			ac.socket = nil
+		}
+		if ac.readDone != nil {
+			close(ac.readDone)
+			ac.readDone = nil
+		}
+	}
 }
 
 // resetTransport attempts to connect to the server. If the connection fails,
@@ -349,15 +569,54 @@ func (ac *addrConn) resetTransport() {
 			return
 		}
 		ac.transport = newTr
-		ac.dopts.bs.Reset()
-
 		ac.updateConnectivityState(connectivity.Ready)
-
 		ac.mu.Unlock()
 
-		// Block until the created transport is down. When this happens, we
-		// attempt to reconnect by starting again from the top
-		<-reconnect.Done()
+		// Only reset the backoff once the connection has stayed Ready for at
+		// least MinConnectTimeout; resetting immediately on Ready would let a
+		// flapping server thrash through the backoff schedule.
+		stable := time.NewTimer(ac.dopts.minConnectTimeout)
+		select {
+		case <-stable.C:
+			ac.dopts.bs.Reset()
+			<-reconnect.Done()
+		case <-reconnect.Done():
+			stable.Stop()
+		}
+	}
+}
+
+// monitorKeepalive enforces ac.dopts.kp against socket, tearing the
+// transport down once it's seen no activity for kp.Time+kp.Timeout (see
+// keepalive.ClientParameters.DeadlineFor for why activity recency stands in
+// for an actual ping/pong round trip). It returns once done is closed, i.e.
+// when ac leaves the Ready state.
+func (ac *addrConn) monitorKeepalive(socket *channelz.Socket, done <-chan struct{}) {
+	kp := ac.dopts.kp
+
+	ticker := time.NewTicker(kp.Time)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if !kp.PermitWithoutStream && !ac.cc.hasInFlightCalls() {
+				continue
+			}
+
+			deadline := kp.DeadlineFor(socket.LastActivity())
+			if deadline.IsZero() || time.Now().Before(deadline) {
+				continue
+			}
+
+			log.Printf("[AddrConn] Keepalive timeout: no activity for %s, closing transport", kp.Time+kp.Timeout)
+			if tr, _ := ac.getTransport(); tr != nil {
+				tr.Close()
+			}
+			return
+		case <-done:
+			return
+		}
 	}
 }
 
@@ -381,7 +640,19 @@ func (ac *addrConn) createTransport(addr string, copts ConnectOptions) (ClientTr
 		reconnect.Fire()
 	}
 
-	tr, err := NewWebsocketClient(ac.cc.ctx, addr, copts, onClose)
+	connectCtx := ac.cc.ctx
+	if ac.dopts.minConnectTimeout > 0 {
+		var cancel context.CancelFunc
+		connectCtx, cancel = context.WithTimeout(ac.cc.ctx, ac.dopts.minConnectTimeout)
+		defer cancel()
+	}
+	if ac.dopts.dialTimeout > 0 {
+		var cancel context.CancelFunc
+		connectCtx, cancel = context.WithTimeout(connectCtx, ac.dopts.dialTimeout)
+		defer cancel()
+	}
+
+	tr, err := NewWebsocketClient(connectCtx, addr, copts, onClose)
 
 	return tr, reconnect, err
 }
@@ -406,4 +677,52 @@ func (ac *addrConn) teardown() {
 	}
 
 	ac.mu.Unlock()
+
+	channelz.RemoveChannel(ac.channelz.ID)
+}
+
+// ccResolverWrapper adapts ClientConn to the resolver.ClientConn interface
+// expected by a resolver.Resolver.
+type ccResolverWrapper struct {
+	cc *ClientConn
+}
+
+func (ccr *ccResolverWrapper) UpdateState(s resolver.State) {
+	ccr.cc.updateResolverState(s)
+}
+
+func (ccr *ccResolverWrapper) ReportError(err error) {
+	log.Println("[ClientConn] resolver error:", err)
+}
+
+// ccBalancerWrapper adapts ClientConn to the balancer.ClientConn interface
+// expected by a balancer.Balancer.
+type ccBalancerWrapper struct {
+	cc *ClientConn
+}
+
+func (ccb *ccBalancerWrapper) NewSubConn(addrs []resolver.Address, _ balancer.NewSubConnOptions) (balancer.SubConn, error) {
+	if len(addrs) == 0 {
+		return nil, errors.New("wsrpc: NewSubConn called with no addresses")
+	}
+	return ccb.cc.newAddrConn(addrs[0].Addr)
+}
+
+func (ccb *ccBalancerWrapper) RemoveSubConn(sc balancer.SubConn) {
+	ac, ok := sc.(*addrConn)
+	if !ok {
+		return
+	}
+
+	ccb.cc.mu.Lock()
+	if cur, ok := ccb.cc.conns[ac.addr]; ok && cur == ac {
+		delete(ccb.cc.conns, ac.addr)
+	}
+	ccb.cc.mu.Unlock()
+
+	ac.teardown()
+}
+
+func (ccb *ccBalancerWrapper) UpdateState(s balancer.State) {
+	ccb.cc.updatePicker(s)
 }
@@ -2,15 +2,56 @@ package wsrpc
 
 import (
 	"crypto/ed25519"
+	"time"
 
 	"github.com/smartcontractkit/wsrpc/internal/backoff"
+	"github.com/smartcontractkit/wsrpc/keepalive"
+	"github.com/smartcontractkit/wsrpc/resolver"
 )
 
+// defaultCallTimeout is used for Invoke and InvokeContext calls when neither
+// a context deadline nor a CallTimeout CallOption has been supplied.
+const defaultCallTimeout = 2 * time.Second
+
+// defaultMinConnectTimeout is the minimum amount of time a Ready connection
+// must remain up before the backoff strategy is reset.
+const defaultMinConnectTimeout = 20 * time.Second
+
+// ConnectParams configures the backoff strategy used by an addrConn when
+// retrying to establish a transport. It mirrors grpc-go's ConnectParams.
+type ConnectParams struct {
+	// BaseDelay is the amount of time to wait before retrying after the
+	// first failure.
+	BaseDelay time.Duration
+	// Multiplier is the factor by which the backoff increases after each
+	// consecutive failure.
+	Multiplier float64
+	// Jitter is the factor by which the backoff is randomized.
+	Jitter float64
+	// MaxDelay caps the backoff at this duration.
+	MaxDelay time.Duration
+	// MinConnectTimeout is the minimum amount of time we spend trying to
+	// establish a connection, and the minimum amount of time a connection
+	// must stay Ready before the backoff strategy is reset.
+	MinConnectTimeout time.Duration
+}
+
 // dialOptions configure a Dial call. dialOptions are set by the DialOption
 // values passed to Dial.
 type dialOptions struct {
-	copts ConnectOptions
-	bs    backoff.Strategy
+	copts             ConnectOptions
+	bs                backoff.Strategy
+	minConnectTimeout time.Duration
+	dialTimeout       time.Duration
+	callTimeout       time.Duration
+
+	resolverBuilder resolver.Builder
+	balancerName    string
+	serviceConfig   string
+
+	unaryInt UnaryClientInterceptor
+
+	kp keepalive.ClientParameters
 }
 
 // DialOption configures how we set up the connection.
@@ -47,11 +88,118 @@ func WithTransportCreds(privKey ed25519.PrivateKey, serverPubKey [ed25519.Public
 	})
 }
 
+// WithDialTimeout returns a DialOption which configures the timeout used
+// while establishing the underlying websocket connection. A zero value
+// disables the timeout.
+func WithDialTimeout(d time.Duration) DialOption {
+	return newFuncDialOption(func(o *dialOptions) {
+		o.dialTimeout = d
+	})
+}
+
+// WithCallTimeout returns a DialOption which sets the default timeout
+// applied to Invoke/InvokeContext calls that don't already have a context
+// deadline or a per-call CallTimeout CallOption.
+func WithCallTimeout(d time.Duration) DialOption {
+	return newFuncDialOption(func(o *dialOptions) {
+		o.callTimeout = d
+	})
+}
+
+// WithResolver returns a DialOption which overrides the resolver.Builder
+// used for this Dial, ignoring the target's scheme when choosing one.
+func WithResolver(b resolver.Builder) DialOption {
+	return newFuncDialOption(func(o *dialOptions) {
+		o.resolverBuilder = b
+	})
+}
+
+// WithBalancerName returns a DialOption which sets the balancer to use by
+// its registered name (e.g. "pick_first", "round_robin").
+func WithBalancerName(name string) DialOption {
+	return newFuncDialOption(func(o *dialOptions) {
+		o.balancerName = name
+	})
+}
+
+// WithDefaultServiceConfig returns a DialOption which stores s as the default
+// service config. The config is not parsed or used by Dial: the balancer is
+// chosen only by WithBalancerName.
+func WithDefaultServiceConfig(s string) DialOption {
+	return newFuncDialOption(func(o *dialOptions) {
+		o.serviceConfig = s
+	})
+}
+
+// WithConnectParams returns a DialOption which sets the backoff strategy
+// used by addrConns when (re)connecting, as described by p.
+func WithConnectParams(p ConnectParams) DialOption {
+	return newFuncDialOption(func(o *dialOptions) {
+		o.bs = backoff.NewExponential(backoff.Config{
+			BaseDelay:  p.BaseDelay,
+			Multiplier: p.Multiplier,
+			Jitter:     p.Jitter,
+			MaxDelay:   p.MaxDelay,
+		})
+		o.minConnectTimeout = p.MinConnectTimeout
+	})
+}
+
+// WithBackoff returns a DialOption which sets a fully custom backoff.Strategy,
+// bypassing ConnectParams entirely.
+func WithBackoff(bs backoff.Strategy) DialOption {
+	return newFuncDialOption(func(o *dialOptions) {
+		o.bs = bs
+	})
+}
+
+// WithKeepaliveParams returns a DialOption which sets the keepalive
+// parameters enforced on each addrConn once it's Ready: if there's been no
+// activity for kp.Time, and either kp.PermitWithoutStream is true or a call
+// is in flight, the addrConn's transport is closed once kp.Timeout passes
+// with still no activity, forcing a reconnect.
+//
+// No websocket ping frame is sent and no pong is awaited: recency of
+// traffic on the connection stands in for a ping/pong round trip; see
+// keepalive.ClientParameters.DeadlineFor.
+func WithKeepaliveParams(kp keepalive.ClientParameters) DialOption {
+	return newFuncDialOption(func(o *dialOptions) {
+		o.kp = kp
+	})
+}
+
+// WithUnaryInterceptor returns a DialOption which sets i as the
+// UnaryClientInterceptor run around every unary Invoke/InvokeContext call.
+// Calling it more than once replaces any interceptor configured by an
+// earlier WithUnaryInterceptor or WithChainUnaryInterceptor call; use
+// WithChainUnaryInterceptor to compose more than one.
+func WithUnaryInterceptor(i UnaryClientInterceptor) DialOption {
+	return newFuncDialOption(func(o *dialOptions) {
+		o.unaryInt = i
+	})
+}
+
+// WithChainUnaryInterceptor returns a DialOption which composes interceptors,
+// in the order given, into a single chain. Any interceptor already
+// configured on the dialOptions runs outermost, ahead of the ones passed
+// here.
+func WithChainUnaryInterceptor(interceptors ...UnaryClientInterceptor) DialOption {
+	return newFuncDialOption(func(o *dialOptions) {
+		if o.unaryInt == nil {
+			o.unaryInt = Chain(interceptors...)
+			return
+		}
+		o.unaryInt = Chain(append([]UnaryClientInterceptor{o.unaryInt}, interceptors...)...)
+	})
+}
+
 func defaultDialOptions() dialOptions {
 	return dialOptions{
 		copts: ConnectOptions{
 			// 	WriteBufferSize: defaultWriteBufSize,
 			// 	ReadBufferSize:  defaultReadBufSize,
 		},
+		callTimeout:       defaultCallTimeout,
+		minConnectTimeout: defaultMinConnectTimeout,
 	}
 }
\ No newline at end of file
@@ -0,0 +1,83 @@
+package wsrpc
+
+import "context"
+
+// UnaryInvoker completes a unary RPC, either by calling the next
+// interceptor in the chain or, for the last interceptor, performing the
+// actual wire call.
+type UnaryInvoker func(ctx context.Context, method string, args, reply interface{}, cc *ClientConn, opts ...CallOption) error
+
+// UnaryClientInterceptor intercepts a unary RPC on the client side. It may
+// inspect or modify the request/response, short-circuit the call entirely,
+// or forward to invoker to let the chain continue.
+type UnaryClientInterceptor func(ctx context.Context, method string, args, reply interface{}, cc *ClientConn, invoker UnaryInvoker, opts ...CallOption) error
+
+// Chain composes interceptors, in the order given, into a single
+// UnaryClientInterceptor. The first interceptor is outermost: it runs first
+// and its invoker calls into the second, and so on, with the final
+// invoker being whatever UnaryInvoker Chain's result is ultimately called
+// with.
+func Chain(interceptors ...UnaryClientInterceptor) UnaryClientInterceptor {
+	if len(interceptors) == 0 {
+		return func(ctx context.Context, method string, args, reply interface{}, cc *ClientConn, invoker UnaryInvoker, opts ...CallOption) error {
+			return invoker(ctx, method, args, reply, cc, opts...)
+		}
+	}
+
+	return func(ctx context.Context, method string, args, reply interface{}, cc *ClientConn, invoker UnaryInvoker, opts ...CallOption) error {
+		return interceptors[0](ctx, method, args, reply, cc, chainInvoker(interceptors[1:], invoker), opts...)
+	}
+}
+
+// chainInvoker builds the UnaryInvoker that interceptors[0] should call to
+// run the rest of the chain, bottoming out at final.
+func chainInvoker(interceptors []UnaryClientInterceptor, final UnaryInvoker) UnaryInvoker {
+	if len(interceptors) == 0 {
+		return final
+	}
+
+	return func(ctx context.Context, method string, args, reply interface{}, cc *ClientConn, opts ...CallOption) error {
+		return interceptors[0](ctx, method, args, reply, cc, chainInvoker(interceptors[1:], final), opts...)
+	}
+}
+
+// UnaryServerInfo contains information about the unary RPC being served,
+// passed to a UnaryServerInterceptor.
+type UnaryServerInfo struct {
+	// FullMethod is the name of the method being invoked.
+	FullMethod string
+}
+
+// UnaryHandler processes a unary RPC on the server side, returning the
+// reply or an error.
+type UnaryHandler func(ctx context.Context, args interface{}) (interface{}, error)
+
+// UnaryServerInterceptor intercepts a unary RPC on the server side. It may
+// inspect or modify the request/response, short-circuit the call entirely,
+// or forward to handler to let the chain continue.
+type UnaryServerInterceptor func(ctx context.Context, args interface{}, info *UnaryServerInfo, handler UnaryHandler) (interface{}, error)
+
+// ChainUnaryServerInterceptors composes server interceptors, in the order
+// given, into a single UnaryServerInterceptor, with the same outermost-first
+// semantics as Chain.
+func ChainUnaryServerInterceptors(interceptors ...UnaryServerInterceptor) UnaryServerInterceptor {
+	if len(interceptors) == 0 {
+		return func(ctx context.Context, args interface{}, info *UnaryServerInfo, handler UnaryHandler) (interface{}, error) {
+			return handler(ctx, args)
+		}
+	}
+
+	return func(ctx context.Context, args interface{}, info *UnaryServerInfo, handler UnaryHandler) (interface{}, error) {
+		return interceptors[0](ctx, args, info, chainUnaryHandler(interceptors[1:], info, handler))
+	}
+}
+
+func chainUnaryHandler(interceptors []UnaryServerInterceptor, info *UnaryServerInfo, final UnaryHandler) UnaryHandler {
+	if len(interceptors) == 0 {
+		return final
+	}
+
+	return func(ctx context.Context, args interface{}) (interface{}, error) {
+		return interceptors[0](ctx, args, info, chainUnaryHandler(interceptors[1:], info, final))
+	}
+}
@@ -0,0 +1,57 @@
+package wsrpc
+
+import (
+	"context"
+	"testing"
+)
+
+func recordingInterceptor(name string, order *[]string) UnaryClientInterceptor {
+	return func(ctx context.Context, method string, args, reply interface{}, cc *ClientConn, invoker UnaryInvoker, opts ...CallOption) error {
+		*order = append(*order, name)
+		return invoker(ctx, method, args, reply, cc, opts...)
+	}
+}
+
+func TestChainRunsInterceptorsOutermostFirst(t *testing.T) {
+	var order []string
+	final := func(ctx context.Context, method string, args, reply interface{}, cc *ClientConn, opts ...CallOption) error {
+		order = append(order, "final")
+		return nil
+	}
+
+	chain := Chain(
+		recordingInterceptor("a", &order),
+		recordingInterceptor("b", &order),
+		recordingInterceptor("c", &order),
+	)
+
+	if err := chain(context.Background(), "m", nil, nil, nil, final); err != nil {
+		t.Fatalf("chain(...) returned error: %v", err)
+	}
+
+	want := []string{"a", "b", "c", "final"}
+	if len(order) != len(want) {
+		t.Fatalf("order = %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("order = %v, want %v", order, want)
+		}
+	}
+}
+
+func TestChainWithNoInterceptorsCallsInvoker(t *testing.T) {
+	called := false
+	final := func(ctx context.Context, method string, args, reply interface{}, cc *ClientConn, opts ...CallOption) error {
+		called = true
+		return nil
+	}
+
+	chain := Chain()
+	if err := chain(context.Background(), "m", nil, nil, nil, final); err != nil {
+		t.Fatalf("chain(...) returned error: %v", err)
+	}
+	if !called {
+		t.Error("final invoker was never called")
+	}
+}
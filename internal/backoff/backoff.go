@@ -0,0 +1,96 @@
+// Package backoff implements the backoff strategy used by wsrpc when
+// retrying to establish a transport connection.
+//
+// This is a trimmed-down copy, with minor modifications, of grpc-go's
+// internal/backoff package.
+package backoff
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Strategy defines the methods that a backoff strategy must implement.
+type Strategy interface {
+	// NextBackOff returns the amount of time to wait before the next retry
+	// given the number of consecutive failures so far.
+	NextBackOff() time.Duration
+	// Reset resets the strategy's internal retry count, e.g. after a
+	// successful, stable connection.
+	Reset()
+}
+
+// Config defines the configuration options for backoff.
+type Config struct {
+	// BaseDelay is the amount of time to wait before retrying after the
+	// first failure.
+	BaseDelay time.Duration
+	// Multiplier is the factor by which the backoff increases after each
+	// consecutive failure.
+	Multiplier float64
+	// Jitter is the factor by which the backoff is randomized.
+	Jitter float64
+	// MaxDelay caps the backoff at this duration.
+	MaxDelay time.Duration
+}
+
+// DefaultConfig is the backoff configuration used by DefaultExponential. It
+// is based on gRPC's default connection backoff configuration.
+var DefaultConfig = Config{
+	BaseDelay:  1.0 * time.Second,
+	Multiplier: 1.6,
+	Jitter:     0.2,
+	MaxDelay:   120 * time.Second,
+}
+
+// DefaultExponential is an Exponential backoff strategy using DefaultConfig.
+var DefaultExponential = NewExponential(DefaultConfig)
+
+// Exponential implements exponential backoff with jitter, following the
+// formula laid out at https://github.com/grpc/grpc/blob/master/doc/connection-backoff.md.
+type Exponential struct {
+	config  Config
+	attempt int
+}
+
+// NewExponential creates an Exponential Strategy using cfg.
+func NewExponential(cfg Config) *Exponential {
+	return &Exponential{config: cfg}
+}
+
+// NextBackOff returns the amount of time to wait before the next retry,
+// increasing the attempt count every time it's called.
+func (bc *Exponential) NextBackOff() time.Duration {
+	d := computeBackoff(bc.config, bc.attempt)
+	bc.attempt++
+	return d
+}
+
+// computeBackoff returns the backoff duration for the given number of prior
+// retries, randomized within +/- Config.Jitter of the calculated value.
+func computeBackoff(cfg Config, retries int) time.Duration {
+	if retries == 0 {
+		return cfg.BaseDelay
+	}
+
+	backoff, max := float64(cfg.BaseDelay), float64(cfg.MaxDelay)
+	for backoff < max && retries > 0 {
+		backoff *= cfg.Multiplier
+		retries--
+	}
+	if backoff > max {
+		backoff = max
+	}
+
+	backoff *= 1 + cfg.Jitter*(rand.Float64()*2-1)
+	if backoff < 0 {
+		return 0
+	}
+	return time.Duration(backoff)
+}
+
+// Reset resets the attempt count, so the next NextBackOff call returns
+// BaseDelay again.
+func (bc *Exponential) Reset() {
+	bc.attempt = 0
+}
@@ -0,0 +1,58 @@
+package backoff
+
+import (
+	"testing"
+	"time"
+)
+
+func TestComputeBackoffFirstAttemptIsBaseDelay(t *testing.T) {
+	cfg := Config{BaseDelay: 1, Multiplier: 1.6, Jitter: 0, MaxDelay: 120}
+	if got := computeBackoff(cfg, 0); got != cfg.BaseDelay {
+		t.Errorf("computeBackoff(cfg, 0) = %v, want %v", got, cfg.BaseDelay)
+	}
+}
+
+func TestComputeBackoffGrowsWithRetries(t *testing.T) {
+	cfg := Config{BaseDelay: 1, Multiplier: 2, Jitter: 0, MaxDelay: 1000}
+	prev := computeBackoff(cfg, 0)
+	for retries := 1; retries < 5; retries++ {
+		cur := computeBackoff(cfg, retries)
+		if cur <= prev {
+			t.Fatalf("computeBackoff(cfg, %d) = %v, want > %v", retries, cur, prev)
+		}
+		prev = cur
+	}
+}
+
+func TestComputeBackoffCapsAtMaxDelay(t *testing.T) {
+	cfg := Config{BaseDelay: 1, Multiplier: 2, Jitter: 0, MaxDelay: 10}
+	if got := computeBackoff(cfg, 100); got != cfg.MaxDelay {
+		t.Errorf("computeBackoff(cfg, 100) = %v, want %v", got, cfg.MaxDelay)
+	}
+}
+
+func TestComputeBackoffJitterStaysWithinBounds(t *testing.T) {
+	cfg := Config{BaseDelay: 100, Multiplier: 1.6, Jitter: 0.2, MaxDelay: 1000}
+	// 100 * 1.6^3 = 409.6, randomized within +/- 20%.
+	unjittered := float64(cfg.BaseDelay) * 1.6 * 1.6 * 1.6
+	lo := time.Duration(unjittered * (1 - cfg.Jitter))
+	hi := time.Duration(unjittered * (1 + cfg.Jitter))
+	for i := 0; i < 50; i++ {
+		got := computeBackoff(cfg, 3)
+		if got < lo || got > hi {
+			t.Fatalf("computeBackoff(cfg, 3) = %v, want within [%v, %v]", got, lo, hi)
+		}
+	}
+}
+
+func TestExponentialResetReturnsToBaseDelay(t *testing.T) {
+	cfg := Config{BaseDelay: 1, Multiplier: 2, Jitter: 0, MaxDelay: 1000}
+	bc := NewExponential(cfg)
+	bc.NextBackOff()
+	bc.NextBackOff()
+	bc.Reset()
+
+	if got := bc.NextBackOff(); got != cfg.BaseDelay {
+		t.Errorf("NextBackOff() after Reset() = %v, want %v", got, cfg.BaseDelay)
+	}
+}
@@ -0,0 +1,74 @@
+// Package keepalive defines keepalive configuration for wsrpc connections,
+// modeled after grpc-go's keepalive package.
+package keepalive
+
+import "time"
+
+// ClientParameters is used by the client to configure when a silent
+// connection is torn down. The client sends no ping frames; a connection
+// that has seen no traffic for Time+Timeout is closed (see DeadlineFor).
+type ClientParameters struct {
+	// Time is the interval at which the client checks the connection for
+	// activity. A value <= 0 disables keepalive enforcement.
+	Time time.Duration
+	// Timeout is how much longer than Time the connection may stay silent
+	// before the client closes it.
+	Timeout time.Duration
+	// PermitWithoutStream, if true, enforces the keepalive timeout even when
+	// there are no in-flight calls on the connection; if false, a connection
+	// with no in-flight calls is left open however long it stays silent.
+	PermitWithoutStream bool
+}
+
+// DeadlineFor returns the time at which a connection that has seen no
+// activity since lastActivity should be torn down for violating cp's
+// keepalive timeout, or the zero Time if cp doesn't enable keepalive
+// pinging (Time <= 0).
+//
+// This chunk's ClientTransport has no ping/pong primitive to send an actual
+// websocket ping frame and wait for its pong, so callers use traffic
+// recency (lastActivity) as a stand-in heartbeat: a connection that's been
+// silent for Time+Timeout is treated the same as one whose ping went
+// unanswered for Timeout.
+func (cp ClientParameters) DeadlineFor(lastActivity time.Time) time.Time {
+	if cp.Time <= 0 {
+		return time.Time{}
+	}
+	return lastActivity.Add(cp.Time + cp.Timeout)
+}
+
+// ServerParameters is used by the server to configure keepalive and
+// connection idleness enforcement for its connections. The wsrpc
+// KeepaliveParams server option only stores it; no code in this module
+// enforces these fields.
+type ServerParameters struct {
+	// MaxConnectionIdle is the amount of time after which an idle connection
+	// (no in-flight calls) is closed.
+	MaxConnectionIdle time.Duration
+	// MaxConnectionAge is the amount of time after which a connection is
+	// closed, regardless of activity.
+	MaxConnectionAge time.Duration
+	// MaxConnectionAgeGrace is the additional time given to in-flight calls
+	// to complete after MaxConnectionAge is reached, before the connection
+	// is forcibly closed.
+	MaxConnectionAgeGrace time.Duration
+	// Time is the interval after which, if there is no activity on the
+	// connection, a keepalive ping is sent.
+	Time time.Duration
+	// Timeout is the amount of time the server waits after sending a
+	// keepalive ping before closing the connection if no pong is received.
+	Timeout time.Duration
+}
+
+// EnforcementPolicy is used by the server to set the minimum keepalive
+// interval it will accept from clients before deciding the client is
+// misbehaving. The wsrpc KeepaliveEnforcementPolicy server option only
+// stores it; no code in this module enforces it.
+type EnforcementPolicy struct {
+	// MinTime is the minimum amount of time a client should wait before
+	// sending a keepalive ping.
+	MinTime time.Duration
+	// PermitWithoutStream, if true, allows clients to send keepalive pings
+	// even when there are no in-flight calls on the connection.
+	PermitWithoutStream bool
+}
@@ -0,0 +1,23 @@
+package keepalive
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDeadlineForDisabledWhenTimeIsZero(t *testing.T) {
+	cp := ClientParameters{Timeout: time.Second}
+	if got := cp.DeadlineFor(time.Now()); !got.IsZero() {
+		t.Errorf("DeadlineFor(...) = %v, want zero Time", got)
+	}
+}
+
+func TestDeadlineForIsTimePlusTimeoutAfterLastActivity(t *testing.T) {
+	cp := ClientParameters{Time: 10 * time.Second, Timeout: 5 * time.Second}
+	last := time.Now()
+
+	want := last.Add(15 * time.Second)
+	if got := cp.DeadlineFor(last); !got.Equal(want) {
+		t.Errorf("DeadlineFor(%v) = %v, want %v", last, got, want)
+	}
+}
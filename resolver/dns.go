@@ -0,0 +1,66 @@
+package resolver
+
+import (
+	"errors"
+	"fmt"
+	"net"
+)
+
+// defaultDNSPort is used when the "dns" scheme's endpoint is a bare host,
+// e.g. "dns:///example.com".
+const defaultDNSPort = "443"
+
+func init() {
+	Register(&dnsBuilder{})
+}
+
+// dnsBuilder implements the "dns" scheme, resolving the endpoint's host
+// once via net.LookupHost and pushing one Address per resolved IP, all
+// sharing the endpoint's port.
+type dnsBuilder struct{}
+
+func (*dnsBuilder) Scheme() string { return "dns" }
+
+func (*dnsBuilder) Build(target Target, cc ClientConn, _ BuildOptions) (Resolver, error) {
+	host, port, err := splitHostPort(target.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	ips, err := net.LookupHost(host)
+	if err != nil {
+		cc.ReportError(err)
+		return &dnsResolver{}, nil
+	}
+
+	addrs := make([]Address, 0, len(ips))
+	for _, ip := range ips {
+		addrs = append(addrs, Address{Addr: fmt.Sprintf("%s:%s", ip, port), ServerName: host})
+	}
+
+	cc.UpdateState(State{Addresses: addrs})
+	return &dnsResolver{}, nil
+}
+
+// splitHostPort is like net.SplitHostPort, except a bare host (no ":port")
+// is accepted and defaults to defaultDNSPort, so "dns:///name" resolves the
+// same as "dns:///name:443".
+func splitHostPort(endpoint string) (host, port string, err error) {
+	host, port, err = net.SplitHostPort(endpoint)
+	if err == nil {
+		return host, port, nil
+	}
+
+	var addrErr *net.AddrError
+	if errors.As(err, &addrErr) && addrErr.Err == "missing port in address" {
+		return endpoint, defaultDNSPort, nil
+	}
+	return "", "", err
+}
+
+type dnsResolver struct{}
+
+// ResolveNow is a no-op; this resolver only resolves once at Build time.
+func (*dnsResolver) ResolveNow(ResolveNowOptions) {}
+
+func (*dnsResolver) Close() {}
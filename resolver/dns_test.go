@@ -0,0 +1,29 @@
+package resolver
+
+import "testing"
+
+func TestSplitHostPortWithPort(t *testing.T) {
+	host, port, err := splitHostPort("example.com:1234")
+	if err != nil {
+		t.Fatalf("splitHostPort(...) returned error: %v", err)
+	}
+	if host != "example.com" || port != "1234" {
+		t.Errorf("splitHostPort(...) = (%q, %q), want (%q, %q)", host, port, "example.com", "1234")
+	}
+}
+
+func TestSplitHostPortBareHostDefaultsPort(t *testing.T) {
+	host, port, err := splitHostPort("example.com")
+	if err != nil {
+		t.Fatalf("splitHostPort(...) returned error: %v", err)
+	}
+	if host != "example.com" || port != defaultDNSPort {
+		t.Errorf("splitHostPort(...) = (%q, %q), want (%q, %q)", host, port, "example.com", defaultDNSPort)
+	}
+}
+
+func TestSplitHostPortInvalidAddress(t *testing.T) {
+	if _, _, err := splitHostPort("[::1"); err == nil {
+		t.Error("splitHostPort(...) returned nil error for a malformed address, want an error")
+	}
+}
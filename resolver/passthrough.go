@@ -0,0 +1,22 @@
+package resolver
+
+func init() {
+	Register(&passthroughBuilder{})
+}
+
+// passthroughBuilder implements the "passthrough" scheme, which performs no
+// resolution at all: the endpoint is used verbatim as the single address.
+type passthroughBuilder struct{}
+
+func (*passthroughBuilder) Scheme() string { return "passthrough" }
+
+func (*passthroughBuilder) Build(target Target, cc ClientConn, _ BuildOptions) (Resolver, error) {
+	cc.UpdateState(State{Addresses: []Address{{Addr: target.Endpoint}}})
+	return &passthroughResolver{}, nil
+}
+
+type passthroughResolver struct{}
+
+func (*passthroughResolver) ResolveNow(ResolveNowOptions) {}
+
+func (*passthroughResolver) Close() {}
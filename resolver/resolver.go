@@ -0,0 +1,86 @@
+// Package resolver defines APIs for target name resolution in wsrpc. It
+// mirrors the shape of grpc-go's resolver package: a Builder is registered
+// per URI scheme, and the Resolver it produces pushes Address updates to a
+// ClientConn as they change.
+package resolver
+
+import "sync"
+
+// Address represents a server the ClientConn may create a connection to.
+type Address struct {
+	// Addr is the server address on which a connection will be established.
+	Addr string
+	// ServerName overrides the name used for transport credential
+	// verification, if non-empty.
+	ServerName string
+}
+
+// State is the state of the resolved addresses, pushed to the ClientConn
+// whenever the resolver has an update.
+type State struct {
+	// Addresses is the latest set of resolved addresses for the target.
+	Addresses []Address
+}
+
+// ClientConn is the interface a Resolver uses to push new states and report
+// resolution errors to the owning wsrpc.ClientConn.
+type ClientConn interface {
+	// UpdateState pushes a new State.
+	UpdateState(State)
+	// ReportError notifies the ClientConn of an error encountered while
+	// resolving the target.
+	ReportError(error)
+}
+
+// Target represents a target parsed from the dial target string, e.g.
+// "static:///h1,h2,h3" parses into Scheme "static" and Endpoint "h1,h2,h3".
+type Target struct {
+	Scheme   string
+	Endpoint string
+}
+
+// BuildOptions contains additional information for Build.
+type BuildOptions struct{}
+
+// ResolveNowOptions contains additional information for ResolveNow.
+type ResolveNowOptions struct{}
+
+// Resolver watches for updates on the target and calls cc.UpdateState
+// whenever the set of resolved addresses changes.
+type Resolver interface {
+	// ResolveNow is called by wsrpc to try to re-resolve the target, e.g.
+	// after a connection failure. Resolvers that can't act on this (e.g.
+	// passthrough) may treat it as a no-op.
+	ResolveNow(ResolveNowOptions)
+	// Close closes the resolver.
+	Close()
+}
+
+// Builder creates a Resolver that will be used to watch a target.
+type Builder interface {
+	// Build creates a new Resolver for the given target.
+	Build(target Target, cc ClientConn, opts BuildOptions) (Resolver, error)
+	// Scheme returns the URI scheme handled by this resolver, e.g. "dns".
+	Scheme() string
+}
+
+var (
+	mu       sync.RWMutex
+	builders = map[string]Builder{}
+)
+
+// Register registers the Builder under b.Scheme(). Registering a second
+// Builder under an already-registered scheme overwrites the first.
+func Register(b Builder) {
+	mu.Lock()
+	defer mu.Unlock()
+	builders[b.Scheme()] = b
+}
+
+// Get returns the Builder registered for scheme, or nil if no Builder is
+// registered for it.
+func Get(scheme string) Builder {
+	mu.RLock()
+	defer mu.RUnlock()
+	return builders[scheme]
+}
@@ -2,6 +2,8 @@ package wsrpc
 
 import (
 	"crypto/ed25519"
+
+	"github.com/smartcontractkit/wsrpc/keepalive"
 )
 
 // A ServerOption sets options such as credentials, codec and keepalive parameters, etc.
@@ -18,6 +20,11 @@ type serverOptions struct {
 	creds            TransportCredentials
 	privKey          ed25519.PrivateKey
 	clientIdentities map[[ed25519.PublicKeySize]byte]string
+
+	unaryInt UnaryServerInterceptor
+
+	kp  keepalive.ServerParameters
+	kep keepalive.EnforcementPolicy
 }
 
 // funcServerOption wraps a function that modifies serverOptions into an
@@ -62,6 +69,49 @@ func ReadBufferSize(s int) ServerOption {
 	})
 }
 
+// UnaryInterceptor returns a ServerOption which sets i as the
+// UnaryServerInterceptor run around every unary RPC handler. Calling it
+// more than once replaces any interceptor configured by an earlier
+// UnaryInterceptor or ChainUnaryInterceptor call; use ChainUnaryInterceptor
+// to compose more than one.
+func UnaryInterceptor(i UnaryServerInterceptor) ServerOption {
+	return newFuncServerOption(func(o *serverOptions) {
+		o.unaryInt = i
+	})
+}
+
+// ChainUnaryInterceptor returns a ServerOption which composes interceptors,
+// in the order given, into a single chain. Any interceptor already
+// configured on the serverOptions runs outermost, ahead of the ones passed
+// here.
+func ChainUnaryInterceptor(interceptors ...UnaryServerInterceptor) ServerOption {
+	return newFuncServerOption(func(o *serverOptions) {
+		if o.unaryInt == nil {
+			o.unaryInt = ChainUnaryServerInterceptors(interceptors...)
+			return
+		}
+		o.unaryInt = ChainUnaryServerInterceptors(append([]UnaryServerInterceptor{o.unaryInt}, interceptors...)...)
+	})
+}
+
+// KeepaliveParams returns a ServerOption which stores kp in the
+// serverOptions. Nothing in this package reads the stored value: the server
+// sends no pings and closes no connection on account of kp.
+func KeepaliveParams(kp keepalive.ServerParameters) ServerOption {
+	return newFuncServerOption(func(o *serverOptions) {
+		o.kp = kp
+	})
+}
+
+// KeepaliveEnforcementPolicy returns a ServerOption which stores kep in the
+// serverOptions. Nothing in this package reads the stored value: no
+// connection is closed for violating kep.
+func KeepaliveEnforcementPolicy(kep keepalive.EnforcementPolicy) ServerOption {
+	return newFuncServerOption(func(o *serverOptions) {
+		o.kep = kep
+	})
+}
+
 var defaultServerOptions = serverOptions{
 	writeBufferSize: defaultWriteBufSize,
 	readBufferSize:  defaultReadBufSize,
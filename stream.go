@@ -0,0 +1,223 @@
+package wsrpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/smartcontractkit/wsrpc/balancer"
+)
+
+// errStreamingNotImplemented is returned by every ClientStream operation
+// that would need to put a frame on the wire. internal/message doesn't have
+// stream-open/stream-msg/half-close/stream-end exchange types yet, so
+// there's no way to encode one; see sendStreamOpen.
+var errStreamingNotImplemented = errors.New("wsrpc: streaming RPCs are not implemented yet (internal/message has no stream exchange types)")
+
+// ClientStream represents the client side of a server-streaming,
+// client-streaming, or bidi-streaming RPC.
+//
+// Note: this is the client half only. The matching ServerStream described
+// alongside it lives on the Server type, which isn't part of this chunk of
+// the tree, so it isn't implemented here. Nor is the client half fully
+// functional yet: NewStream fails with errStreamingNotImplemented until
+// internal/message grows stream exchange types (see sendStreamOpen).
+type ClientStream interface {
+	// SendMsg sends m to the server on the stream.
+	SendMsg(m interface{}) error
+	// RecvMsg blocks until a message from the server is unmarshalled into m,
+	// returning io.EOF once the server has ended the stream.
+	RecvMsg(m interface{}) error
+	// CloseSend half-closes the stream, telling the server the client has
+	// no more messages to send.
+	CloseSend() error
+}
+
+// NewStream opens a new stream to method on a ready subconn chosen by the
+// balancer's picker. Cancelling ctx tears the stream down locally; no frame
+// is sent to the server.
+//
+// NewStream always fails with errStreamingNotImplemented; see ClientStream.
+func (cc *ClientConn) NewStream(ctx context.Context, method string) (ClientStream, error) {
+	cc.pickerMu.Lock()
+	picker := cc.picker
+	cc.pickerMu.Unlock()
+
+	if picker == nil {
+		return nil, errors.New("connection is not ready")
+	}
+
+	pr, err := picker.Pick(balancer.PickInfo{Method: method})
+	if err != nil {
+		return nil, err
+	}
+
+	ac, ok := pr.SubConn.(*addrConn)
+	if !ok {
+		return nil, errors.New("connection is not ready")
+	}
+	if tr, _ := ac.getTransport(); tr == nil {
+		return nil, errors.New("connection is not ready")
+	}
+
+	sctx, cancel := context.WithCancel(ctx)
+	cs := &clientStream{
+		ctx:    sctx,
+		cancel: cancel,
+		cc:     cc,
+		ac:     ac,
+		id:     uuid.NewString(),
+		method: method,
+		recvCh: make(chan []byte, 16),
+		done:   make(chan struct{}),
+	}
+
+	cc.mu.Lock()
+	cc.streams[cs.id] = cs
+	cc.mu.Unlock()
+
+	if err := cs.sendStreamOpen(); err != nil {
+		cc.removeStream(cs.id)
+		cancel()
+		return nil, err
+	}
+
+	go func() {
+		<-sctx.Done()
+		cc.removeStream(cs.id)
+	}()
+
+	return cs, nil
+}
+
+// removeStream deregisters a stream from the streams map.
+func (cc *ClientConn) removeStream(id string) {
+	cc.mu.Lock()
+	delete(cc.streams, id)
+	cc.mu.Unlock()
+}
+
+// handleStreamMessage routes an inbound stream-msg frame's payload to the
+// buffered channel of the matching clientStream.
+//
+// TODO - unreachable until internal/message grows a StreamMessage exchange
+// type for handleRead to decode and dispatch here; see NewStream.
+func (cc *ClientConn) handleStreamMessage(streamID string, payload []byte) {
+	cc.mu.RLock()
+	cs, ok := cc.streams[streamID]
+	cc.mu.RUnlock()
+	if !ok {
+		return
+	}
+
+	select {
+	case cs.recvCh <- payload:
+	case <-cs.ctx.Done():
+	}
+}
+
+// handleStreamEnd marks the matching clientStream as finished, recording an
+// error if the server reported one, and cancels the stream's context so the
+// monitor goroutine started in NewStream can exit.
+//
+// TODO - unreachable until internal/message grows a StreamEnd exchange type
+// for handleRead to decode and dispatch here; see NewStream.
+func (cc *ClientConn) handleStreamEnd(streamID string, errMsg string) {
+	cc.mu.Lock()
+	cs, ok := cc.streams[streamID]
+	delete(cc.streams, streamID)
+	cc.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	if errMsg != "" {
+		cs.mu.Lock()
+		cs.err = errors.New(errMsg)
+		cs.mu.Unlock()
+	}
+	close(cs.done)
+	cs.cancel()
+}
+
+// clientStream is the ClientConn-side handle for a single stream, keyed by
+// id in ClientConn.streams.
+type clientStream struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	cc     *ClientConn
+	ac     *addrConn
+	id     string
+	method string
+
+	recvCh chan []byte
+	done   chan struct{}
+
+	closeSendOnce sync.Once
+
+	mu  sync.Mutex
+	err error
+}
+
+func (cs *clientStream) SendMsg(m interface{}) error {
+	payload, err := MarshalProtoMessage(m)
+	if err != nil {
+		return err
+	}
+
+	return cs.sendStreamMessage(payload)
+}
+
+func (cs *clientStream) RecvMsg(m interface{}) error {
+	select {
+	case b := <-cs.recvCh:
+		return UnmarshalProtoMessage(b, m)
+	case <-cs.done:
+		cs.mu.Lock()
+		err := cs.err
+		cs.mu.Unlock()
+		if err != nil {
+			return err
+		}
+		return io.EOF
+	case <-cs.ctx.Done():
+		return cs.ctx.Err()
+	}
+}
+
+func (cs *clientStream) CloseSend() error {
+	var sendErr error
+	cs.closeSendOnce.Do(func() {
+		sendErr = cs.sendStreamHalfClose()
+	})
+	return sendErr
+}
+
+// sendStreamOpen notifies the server that a new stream has been opened.
+//
+// TODO - internal/message needs stream-open/stream-msg/half-close/
+// stream-end exchange types (Message_StreamOpen etc.) alongside
+// Request/Response before stream frames can go over the wire; wire this up
+// once those message types land. Until then this fails loudly instead of
+// silently reporting success for a frame that was never sent.
+func (cs *clientStream) sendStreamOpen() error {
+	return errStreamingNotImplemented
+}
+
+// sendStreamMessage sends payload to the server on the stream.
+//
+// TODO - see sendStreamOpen.
+func (cs *clientStream) sendStreamMessage(payload []byte) error {
+	return errStreamingNotImplemented
+}
+
+// sendStreamHalfClose tells the server the client has no more messages to
+// send on the stream.
+//
+// TODO - see sendStreamOpen.
+func (cs *clientStream) sendStreamHalfClose() error {
+	return errStreamingNotImplemented
+}